@@ -0,0 +1,96 @@
+package haproxyctl
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// SendAction applies action to the backend/server pair identified by
+// backendName and serverName, over the Runtime API socket if one is
+// configured or the HTTP stats page otherwise.
+func (c *HAProxyConfig) SendAction(action Action, backendName string, serverName string) error {
+	if c.IsSocket() {
+		return c.sendActionSocket(action, backendName, serverName)
+	}
+	return c.sendActionHTTP(action, backendName, serverName)
+}
+
+func (c *HAProxyConfig) sendActionSocket(action Action, backendName string, serverName string) error {
+	cmd, ok := runtimeCommand(action, backendName, serverName)
+	if !ok {
+		return fmt.Errorf("haproxyctl: action %q has no Runtime API equivalent", action)
+	}
+	rc, err := c.RunCommand(cmd)
+	if err != nil {
+		return err
+	}
+	return drainAndClose(rc)
+}
+
+func (c *HAProxyConfig) sendActionHTTP(action Action, backendName string, serverName string) error {
+	form := url.Values{
+		"s":      {fmt.Sprintf("%s/%s", backendName, serverName)},
+		"action": {string(action)},
+	}
+	rc, err := c.post(c.StatsPath, form)
+	if err != nil {
+		return err
+	}
+	return drainAndClose(rc)
+}
+
+// drainAndClose reads rc to completion before closing it, so the command or
+// request is known to have finished before the caller moves on.
+func drainAndClose(rc io.ReadCloser) error {
+	_, err := io.Copy(io.Discard, rc)
+	if closeErr := rc.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// runtimeServerStates maps the HTTP stats page actions that set a server's
+// administrative state to their "set server ... state ..." equivalent.
+var runtimeServerStates = map[Action]string{
+	ActionSetStateToReady: "ready",
+	ActionSetStateToDrain: "drain",
+	ActionSetStateToMaint: "maint",
+}
+
+// runtimeCommand returns the Runtime API command that performs action
+// against backendName/serverName, and whether one exists. Every HTTP stats
+// page action has a Runtime API equivalent; this just maps each to its
+// "set server"/"enable"/"disable"/"shutdown" form.
+func runtimeCommand(action Action, backendName, serverName string) (string, bool) {
+	target := fmt.Sprintf("%s/%s", backendName, serverName)
+
+	if state, ok := runtimeServerStates[action]; ok {
+		return fmt.Sprintf("set server %s state %s", target, state), true
+	}
+
+	switch action {
+	case ActionHealthForceUp:
+		return fmt.Sprintf("set server %s health up", target), true
+	case ActionHealthForceNoLB:
+		return fmt.Sprintf("set server %s health stopping", target), true
+	case ActionHealthForceDown:
+		return fmt.Sprintf("set server %s health down", target), true
+	case ActionHealthDisableChecks:
+		return fmt.Sprintf("disable health %s", target), true
+	case ActionHealthEnableChecks:
+		return fmt.Sprintf("enable health %s", target), true
+	case ActionAgentDisablechecks:
+		return fmt.Sprintf("disable agent %s", target), true
+	case ActionAgentEnablechecks:
+		return fmt.Sprintf("enable agent %s", target), true
+	case ActionAgentForceUp:
+		return fmt.Sprintf("set server %s agent up", target), true
+	case ActionAgentForceDown:
+		return fmt.Sprintf("set server %s agent down", target), true
+	case ActionKillSessions:
+		return fmt.Sprintf("shutdown sessions server %s", target), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,42 @@
+package haproxyctl
+
+import "testing"
+
+func TestRuntimeCommand(t *testing.T) {
+	tests := []struct {
+		action Action
+		want   string
+	}{
+		{ActionSetStateToReady, "set server web/web1 state ready"},
+		{ActionSetStateToDrain, "set server web/web1 state drain"},
+		{ActionSetStateToMaint, "set server web/web1 state maint"},
+		{ActionHealthForceUp, "set server web/web1 health up"},
+		{ActionHealthForceNoLB, "set server web/web1 health stopping"},
+		{ActionHealthForceDown, "set server web/web1 health down"},
+		{ActionHealthDisableChecks, "disable health web/web1"},
+		{ActionHealthEnableChecks, "enable health web/web1"},
+		{ActionAgentDisablechecks, "disable agent web/web1"},
+		{ActionAgentEnablechecks, "enable agent web/web1"},
+		{ActionAgentForceUp, "set server web/web1 agent up"},
+		{ActionAgentForceDown, "set server web/web1 agent down"},
+		{ActionKillSessions, "shutdown sessions server web/web1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			got, ok := runtimeCommand(tt.action, "web", "web1")
+			if !ok {
+				t.Fatalf("runtimeCommand(%q) reported no Runtime API equivalent", tt.action)
+			}
+			if got != tt.want {
+				t.Errorf("runtimeCommand(%q) = %q, want %q", tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimeCommandUnknownAction(t *testing.T) {
+	if _, ok := runtimeCommand(Action("bogus"), "web", "web1"); ok {
+		t.Error("runtimeCommand(bogus) reported a Runtime API equivalent, want false")
+	}
+}
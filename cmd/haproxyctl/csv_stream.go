@@ -0,0 +1,190 @@
+package haproxyctl
+
+import (
+	"encoding/csv"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StatisticsStream opens the stats CSV, over the Runtime API socket if one
+// is configured or the HTTP stats page otherwise, and returns a
+// StatisticIterator that decodes it incrementally straight off the
+// connection or HTTP response body, without buffering the raw response.
+// The caller must Close the iterator once done (Next does this
+// automatically once the stream is exhausted or errors).
+func (c *HAProxyConfig) StatisticsStream() (*StatisticIterator, error) {
+	rc, err := c.statsReader()
+	if err != nil {
+		return nil, err
+	}
+	return newStatisticIterator(rc)
+}
+
+// StatisticIterator decodes HAProxy stats CSV records one at a time,
+// reading incrementally from the underlying connection or HTTP response
+// body rather than buffering it.
+type StatisticIterator struct {
+	closer io.Closer
+	reader *csv.Reader
+	fields []int
+	cur    Statistic
+	err    error
+	closed bool
+}
+
+func newStatisticIterator(rc io.ReadCloser) (*StatisticIterator, error) {
+	reader := csv.NewReader(rc)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		// An empty response is zero Statistics, not an error.
+		rc.Close()
+		return &StatisticIterator{closer: rc, closed: true}, nil
+	}
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+
+	return &StatisticIterator{
+		closer: rc,
+		reader: reader,
+		fields: csvFieldsByTag(header),
+	}, nil
+}
+
+// Next advances the iterator to the next Statistic, returning false once the
+// stream is exhausted or an error occurs, at which point it closes the
+// underlying connection. Check Err after Next returns false.
+func (it *StatisticIterator) Next() bool {
+	if it.err != nil || it.reader == nil {
+		return false
+	}
+
+	record, err := it.reader.Read()
+	if err != nil {
+		if err != io.EOF {
+			it.err = err
+		}
+		it.Close()
+		return false
+	}
+
+	var stat Statistic
+	if err := unmarshalStatisticRecord(&stat, record, it.fields); err != nil {
+		it.err = err
+		it.Close()
+		return false
+	}
+	it.cur = stat
+	return true
+}
+
+// Stat returns the Statistic decoded by the most recent call to Next.
+func (it *StatisticIterator) Stat() Statistic {
+	return it.cur
+}
+
+// Err returns the first error encountered while iterating, if any.
+func (it *StatisticIterator) Err() error {
+	return it.err
+}
+
+// Close releases the underlying connection or HTTP response body. It is
+// called automatically once Next is exhausted, and is safe to call more
+// than once, so callers that stop iterating early should still call it.
+func (it *StatisticIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	return it.closer.Close()
+}
+
+// unmarshalStatistics decodes a full HAProxy stats CSV document into a
+// Statistics slice, on top of StatisticIterator.
+func unmarshalStatistics(rc io.ReadCloser) (Statistics, error) {
+	it, err := newStatisticIterator(rc)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	stats := Statistics{}
+	for it.Next() {
+		stats = append(stats, it.Stat())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// csvFieldsByTag maps each CSV header column to the index of the Statistic
+// struct field carrying the matching `csv` tag, built once per stream. A
+// column HAProxy emits that Statistic does not model, or a column Statistic
+// expects that the stream omits, is tolerated rather than an error: newer
+// HAProxy versions routinely add fields across releases.
+func csvFieldsByTag(header []string) []int {
+	tagToField := map[string]int{}
+	t := reflect.TypeOf(Statistic{})
+	for i := 0; i < t.NumField(); i++ {
+		tagToField[t.Field(i).Tag.Get("csv")] = i
+	}
+
+	fields := make([]int, len(header))
+	for i, col := range header {
+		idx, ok := tagToField[strings.TrimSpace(col)]
+		if !ok {
+			idx = -1
+		}
+		fields[i] = idx
+	}
+	return fields
+}
+
+func unmarshalStatisticRecord(stat *Statistic, record []string, fields []int) error {
+	v := reflect.ValueOf(stat).Elem()
+	for i, value := range record {
+		if i >= len(fields) || fields[i] == -1 {
+			continue
+		}
+		if err := setField(v.Field(fields[i]), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, value string) error {
+	if u, ok := field.Addr().Interface().(interface{ UnmarshalCSV(string) error }); ok {
+		return u.UnmarshalCSV(value)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Uint64:
+		if value == "" {
+			return nil
+		}
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Int:
+		if value == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(n))
+	}
+	return nil
+}
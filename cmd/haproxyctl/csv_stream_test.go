@@ -0,0 +1,99 @@
+package haproxyctl
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCsvFieldsByTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+		want   map[int]bool // index into header that should map to a known field
+	}{
+		{
+			name:   "known columns map",
+			header: []string{"# pxname", "svname", "status"},
+			want:   map[int]bool{0: true, 1: true, 2: true},
+		},
+		{
+			name:   "unknown column is tolerated",
+			header: []string{"# pxname", "svname", "some_future_field"},
+			want:   map[int]bool{0: true, 1: true, 2: false},
+		},
+		{
+			name:   "whitespace around header is trimmed",
+			header: []string{" # pxname ", " svname "},
+			want:   map[int]bool{0: true, 1: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := csvFieldsByTag(tt.header)
+			for i, wantKnown := range tt.want {
+				gotKnown := fields[i] != -1
+				if gotKnown != wantKnown {
+					t.Errorf("column %d (%q): got known=%v, want known=%v", i, tt.header[i], gotKnown, wantKnown)
+				}
+			}
+		})
+	}
+}
+
+func TestStatisticIteratorMissingAndUnknownColumns(t *testing.T) {
+	csv := "# pxname,svname,some_future_field,status\n" +
+		"web,web1,whatever,UP\n"
+
+	it, err := newStatisticIterator(io.NopCloser(strings.NewReader(csv)))
+	if err != nil {
+		t.Fatalf("newStatisticIterator: %v", err)
+	}
+	defer it.Close()
+
+	if !it.Next() {
+		t.Fatalf("Next: expected a record, got none (err=%v)", it.Err())
+	}
+
+	stat := it.Stat()
+	if stat.BackendName != "web" || stat.FrontendName != "web1" {
+		t.Errorf("unexpected stat: %+v", stat)
+	}
+	if stat.Status != StatusUp {
+		t.Errorf("Status = %q, want %q", stat.Status, StatusUp)
+	}
+
+	if it.Next() {
+		t.Fatalf("Next: expected stream to be exhausted, got another record")
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+}
+
+func TestStatisticIteratorMissingColumn(t *testing.T) {
+	// Statistic models "weight" but this stream omits it entirely.
+	csv := "# pxname,svname\nweb,web1\n"
+
+	stats, err := unmarshalStatistics(io.NopCloser(strings.NewReader(csv)))
+	if err != nil {
+		t.Fatalf("unmarshalStatistics: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("len(stats) = %d, want 1", len(stats))
+	}
+	if stats[0].Weight != 0 {
+		t.Errorf("Weight = %d, want 0 (zero value for an absent column)", stats[0].Weight)
+	}
+}
+
+func TestUnmarshalStatisticsEmptyStream(t *testing.T) {
+	stats, err := unmarshalStatistics(io.NopCloser(strings.NewReader("")))
+	if err != nil {
+		t.Fatalf("unmarshalStatistics: %v", err)
+	}
+	if len(stats) != 0 {
+		t.Errorf("len(stats) = %d, want 0", len(stats))
+	}
+}
@@ -10,9 +10,17 @@ import (
 
 type ConfigOption func(*HAProxyConfig) error
 
-// NewHAProxyConfig creates a new HAProxyConfig object
+// NewHAProxyConfig creates a new HAProxyConfig object. proxyUrl may be the
+// URL of the HTTP stats page, or a Runtime API socket given as
+// unix:///path/to/haproxy.sock or tcp://host:port.
 func NewHAProxyConfig(proxyUrl string, opts ...ConfigOption) (*HAProxyConfig, error) {
-	endpoint, err := url.Parse(fmt.Sprintf("%s/", strings.TrimRight(proxyUrl, "/")))
+	var endpoint *url.URL
+	var err error
+	if isSocketURL(proxyUrl) {
+		endpoint, err = url.Parse(proxyUrl)
+	} else {
+		endpoint, err = url.Parse(fmt.Sprintf("%s/", strings.TrimRight(proxyUrl, "/")))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +49,18 @@ func WithAuthString(auth string) ConfigOption {
 	}
 }
 
+// SetCredentialsFromAuthString sets Username/Password from a "user:password"
+// string, as accepted by WithAuthString.
+func (c *HAProxyConfig) SetCredentialsFromAuthString(auth string) error {
+	user, pass, ok := strings.Cut(auth, ":")
+	if !ok {
+		return fmt.Errorf("haproxyctl: auth string must be in \"user:password\" form")
+	}
+	c.Username = user
+	c.Password = pass
+	return nil
+}
+
 func WithAuthInfo(user string, pass string) ConfigOption {
 	return func(c *HAProxyConfig) error {
 		c.Username = user
@@ -56,16 +76,41 @@ func WithHttpClient(client *http.Client) ConfigOption {
 	}
 }
 
+// WithTimeout sets the timeout used when dialing a Runtime API socket.
+func WithTimeout(timeout time.Duration) ConfigOption {
+	return func(c *HAProxyConfig) error {
+		c.Timeout = timeout
+		return nil
+	}
+}
+
+// WithTimeoutOp sets the timeout for a single Runtime API operation, covering
+// the time between dialing and reading the final byte of the response.
+func WithTimeoutOp(timeout time.Duration) ConfigOption {
+	return func(c *HAProxyConfig) error {
+		c.TimeoutOp = timeout
+		return nil
+	}
+}
+
 // HAProxyConfig holds the basic configuration options for haproxyctl
 type HAProxyConfig struct {
 	URL       url.URL
 	StatsPath string
 	Username  string
 	Password  string
+	Timeout   time.Duration
+	TimeoutOp time.Duration
 	client    *http.Client
 	setupdone bool
 }
 
+// isSocketURL reports whether raw names a Runtime API socket (unix:// or
+// tcp://) rather than an HTTP stats page.
+func isSocketURL(raw string) bool {
+	return strings.HasPrefix(raw, "unix://") || strings.HasPrefix(raw, "tcp://")
+}
+
 func (c *HAProxyConfig) setupClient() {
 	if c.setupdone {
 		return
@@ -85,105 +130,105 @@ type Statistics []Statistic
 
 // Statistic contains a set of HAProxy Statistics
 type Statistic struct {
-	BackendName             string    `csv:"# pxname"`
-	FrontendName            string    `csv:"svname"`
-	QueueCurrent            uint64    `csv:"qcur"`
-	QueueMax                uint64    `csv:"qmax"`
-	SessionsCurrent         uint64    `csv:"scur"`
-	SessionsMax             uint64    `csv:"smax"`
-	SessionLimit            uint64    `csv:"slim"`
-	SessionsTotal           uint64    `csv:"stot"`
-	BytesIn                 uint64    `csv:"bin"`
-	BytesOut                uint64    `csv:"bout"`
-	DeniedRequests          uint64    `csv:"dreq"`
-	DeniedResponses         uint64    `csv:"dresp"`
-	ErrorsRequests          uint64    `csv:"ereq"`
-	ErrorsConnections       uint64    `csv:"econ"`
-	ErrorsResponses         uint64    `csv:"eresp"`
-	WarningsRetries         uint64    `csv:"wretr"`
-	WarningsDispatches      uint64    `csv:"wredis"`
-	Status                  string    `csv:"status"`
-	Weight                  uint64    `csv:"weight"`
-	IsActive                uint64    `csv:"act"`
-	IsBackup                uint64    `csv:"bck"`
-	CheckFailed             uint64    `csv:"chkfail"`
-	CheckDowned             uint64    `csv:"chkdown"`
-	StatusLastChanged       Duration  `csv:"lastchg"`
-	Downtime                Duration  `csv:"downtime"`
-	QueueLimit              uint64    `csv:"qlimit"`
-	ProcessID               uint64    `csv:"pid"`
-	ProxyID                 uint64    `csv:"iid"`
-	ServiceID               uint64    `csv:"sid"`
-	Throttle                uint64    `csv:"throttle"`
-	LBTotal                 uint64    `csv:"lbtot"`
-	Tracked                 uint64    `csv:"tracked"`
-	Type                    EntryType `csv:"type"`
-	Rate                    uint64    `csv:"rate"`
-	RateLimit               uint64    `csv:"rate_lim"`
-	RateMax                 uint64    `csv:"rate_max"`
-	CheckStatus             string    `csv:"check_status"`
-	CheckCode               string    `csv:"check_code"`
-	CheckDuration           uint64    `csv:"check_duration"`
-	HTTPResponse1xx         uint64    `csv:"hrsp_1xx"`
-	HTTPResponse2xx         uint64    `csv:"hrsp_2xx"`
-	HTTPResponse3xx         uint64    `csv:"hrsp_3xx"`
-	HTTPResponse4xx         uint64    `csv:"hrsp_4xx"`
-	HTTPResponse5xx         uint64    `csv:"hrsp_5xx"`
-	HTTPResponseOther       uint64    `csv:"hrsp_other"`
-	CheckFailedDets         uint64    `csv:"hanafail"`
-	RequestRate             uint64    `csv:"req_rate"`
-	RequestRateMax          uint64    `csv:"req_rate_max"`
-	RequestTotal            uint64    `csv:"req_tot"`
-	AbortedByClient         uint64    `csv:"cli_abrt"`
-	AbortedByServer         uint64    `csv:"srv_abrt"`
-	CompressedBytesIn       uint64    `csv:"comp_in"`
-	CompressedBytesOut      uint64    `csv:"comp_out"`
-	CompressedBytesBypassed uint64    `csv:"comp_byp"`
-	CompressedResponses     uint64    `csv:"comp_rsp"`
-	LastSession             Duration  `csv:"lastsess"`
-	LastCheck               string    `csv:"last_chk"`
-	LastAgentCheck          string    `csv:"last_agt"`
-	AvgQueueTime            uint64    `csv:"qtime"`
-	AvgConnectTime          uint64    `csv:"ctime"`
-	AvgResponseTime         uint64    `csv:"rtime"`
-	AvgTotalTime            uint64    `csv:"ttime"`
-	AgentStatus             uint64    `csv:"agent_status"`
-	AgentCode               uint64    `csv:"agent_code"`
-	AgentDuration           uint64    `csv:"agent_duration"`
-	CheckDesc               string    `csv:"check_desc"`
-	AgentDesc               string    `csv:"agent_desc"`
-	CheckRise               uint64    `csv:"check_rise"`
-	CheckFall               uint64    `csv:"check_fall"`
-	CheckHealth             uint64    `csv:"check_health"`
-	AgentRise               uint64    `csv:"agent_rise"`
-	AgentFall               uint64    `csv:"agent_fall"`
-	AgentHealth             uint64    `csv:"agent_health"`
-	Address                 string    `csv:"addr"`
-	Cookie                  uint64    `csv:"cookie"`
-	Mode                    string    `csv:"mode"`
-	LBAlgorithm             string    `csv:"algo"`
-	ConnRate                uint64    `csv:"conn_rate"`
-	ConnRateMax             uint64    `csv:"conn_rate_max"`
-	ConnTotal               uint64    `csv:"conn_tot"`
-	Intercepted             uint64    `csv:"intercepted"`
-	DeniedCon               uint64    `csv:"dcon"`
-	DeniedSes               uint64    `csv:"dses"`
-	Wrew                    uint64    `csv:"wrew"`
-	Connect                 uint64    `csv:"connect"`
-	Reuse                   uint64    `csv:"reuse"`
-	CacheLookups            uint64    `csv:"cache_lookups"`
-	CacheHits               uint64    `csv:"cache_hits"`
-	IdleConAvail            uint64    `csv:"srv_icur"`
-	IdleConLimit            uint64    `csv:"src_ilim"`
-	QtimeMax                uint64    `csv:"qtime_max"`
-	CtimeMax                uint64    `csv:"ctime_max"`
-	RtimeMax                uint64    `csv:"rtime_max"`
-	TtimeMax                uint64    `csv:"ttime_max"`
-	InternalErr             uint64    `csv:"eint"`
-	IdleConnCur             uint64    `csv:"idle_conn_cur"`
-	SafeConnCur             uint64    `csv:"safe_conn_cur"`
-	UsedConnCur             uint64    `csv:"used_conn_cur"`
-	NeedConnEst             uint64    `csv:"need_conn_est"`
+	BackendName             string       `csv:"# pxname"`
+	FrontendName            string       `csv:"svname"`
+	QueueCurrent            uint64       `csv:"qcur"`
+	QueueMax                uint64       `csv:"qmax"`
+	SessionsCurrent         uint64       `csv:"scur"`
+	SessionsMax             uint64       `csv:"smax"`
+	SessionLimit            uint64       `csv:"slim"`
+	SessionsTotal           uint64       `csv:"stot"`
+	BytesIn                 uint64       `csv:"bin"`
+	BytesOut                uint64       `csv:"bout"`
+	DeniedRequests          uint64       `csv:"dreq"`
+	DeniedResponses         uint64       `csv:"dresp"`
+	ErrorsRequests          uint64       `csv:"ereq"`
+	ErrorsConnections       uint64       `csv:"econ"`
+	ErrorsResponses         uint64       `csv:"eresp"`
+	WarningsRetries         uint64       `csv:"wretr"`
+	WarningsDispatches      uint64       `csv:"wredis"`
+	Status                  ServerStatus `csv:"status"`
+	Weight                  uint64       `csv:"weight"`
+	IsActive                uint64       `csv:"act"`
+	IsBackup                uint64       `csv:"bck"`
+	CheckFailed             uint64       `csv:"chkfail"`
+	CheckDowned             uint64       `csv:"chkdown"`
+	StatusLastChanged       Duration     `csv:"lastchg"`
+	Downtime                Duration     `csv:"downtime"`
+	QueueLimit              uint64       `csv:"qlimit"`
+	ProcessID               uint64       `csv:"pid"`
+	ProxyID                 uint64       `csv:"iid"`
+	ServiceID               uint64       `csv:"sid"`
+	Throttle                uint64       `csv:"throttle"`
+	LBTotal                 uint64       `csv:"lbtot"`
+	Tracked                 uint64       `csv:"tracked"`
+	Type                    EntryType    `csv:"type"`
+	Rate                    uint64       `csv:"rate"`
+	RateLimit               uint64       `csv:"rate_lim"`
+	RateMax                 uint64       `csv:"rate_max"`
+	CheckStatus             string       `csv:"check_status"`
+	CheckCode               string       `csv:"check_code"`
+	CheckDuration           uint64       `csv:"check_duration"`
+	HTTPResponse1xx         uint64       `csv:"hrsp_1xx"`
+	HTTPResponse2xx         uint64       `csv:"hrsp_2xx"`
+	HTTPResponse3xx         uint64       `csv:"hrsp_3xx"`
+	HTTPResponse4xx         uint64       `csv:"hrsp_4xx"`
+	HTTPResponse5xx         uint64       `csv:"hrsp_5xx"`
+	HTTPResponseOther       uint64       `csv:"hrsp_other"`
+	CheckFailedDets         uint64       `csv:"hanafail"`
+	RequestRate             uint64       `csv:"req_rate"`
+	RequestRateMax          uint64       `csv:"req_rate_max"`
+	RequestTotal            uint64       `csv:"req_tot"`
+	AbortedByClient         uint64       `csv:"cli_abrt"`
+	AbortedByServer         uint64       `csv:"srv_abrt"`
+	CompressedBytesIn       uint64       `csv:"comp_in"`
+	CompressedBytesOut      uint64       `csv:"comp_out"`
+	CompressedBytesBypassed uint64       `csv:"comp_byp"`
+	CompressedResponses     uint64       `csv:"comp_rsp"`
+	LastSession             Duration     `csv:"lastsess"`
+	LastCheck               string       `csv:"last_chk"`
+	LastAgentCheck          string       `csv:"last_agt"`
+	AvgQueueTime            uint64       `csv:"qtime"`
+	AvgConnectTime          uint64       `csv:"ctime"`
+	AvgResponseTime         uint64       `csv:"rtime"`
+	AvgTotalTime            uint64       `csv:"ttime"`
+	AgentStatus             uint64       `csv:"agent_status"`
+	AgentCode               uint64       `csv:"agent_code"`
+	AgentDuration           uint64       `csv:"agent_duration"`
+	CheckDesc               string       `csv:"check_desc"`
+	AgentDesc               string       `csv:"agent_desc"`
+	CheckRise               uint64       `csv:"check_rise"`
+	CheckFall               uint64       `csv:"check_fall"`
+	CheckHealth             uint64       `csv:"check_health"`
+	AgentRise               uint64       `csv:"agent_rise"`
+	AgentFall               uint64       `csv:"agent_fall"`
+	AgentHealth             uint64       `csv:"agent_health"`
+	Address                 string       `csv:"addr"`
+	Cookie                  uint64       `csv:"cookie"`
+	Mode                    string       `csv:"mode"`
+	LBAlgorithm             string       `csv:"algo"`
+	ConnRate                uint64       `csv:"conn_rate"`
+	ConnRateMax             uint64       `csv:"conn_rate_max"`
+	ConnTotal               uint64       `csv:"conn_tot"`
+	Intercepted             uint64       `csv:"intercepted"`
+	DeniedCon               uint64       `csv:"dcon"`
+	DeniedSes               uint64       `csv:"dses"`
+	Wrew                    uint64       `csv:"wrew"`
+	Connect                 uint64       `csv:"connect"`
+	Reuse                   uint64       `csv:"reuse"`
+	CacheLookups            uint64       `csv:"cache_lookups"`
+	CacheHits               uint64       `csv:"cache_hits"`
+	IdleConAvail            uint64       `csv:"srv_icur"`
+	IdleConLimit            uint64       `csv:"src_ilim"`
+	QtimeMax                uint64       `csv:"qtime_max"`
+	CtimeMax                uint64       `csv:"ctime_max"`
+	RtimeMax                uint64       `csv:"rtime_max"`
+	TtimeMax                uint64       `csv:"ttime_max"`
+	InternalErr             uint64       `csv:"eint"`
+	IdleConnCur             uint64       `csv:"idle_conn_cur"`
+	SafeConnCur             uint64       `csv:"safe_conn_cur"`
+	UsedConnCur             uint64       `csv:"used_conn_cur"`
+	NeedConnEst             uint64       `csv:"need_conn_est"`
 }
 
 // Duration is a type that we can attach CSV marshalling to for getting time.Duration
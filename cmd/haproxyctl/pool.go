@@ -0,0 +1,173 @@
+package haproxyctl
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// HAProxyPool fans requests out to every worker of an nbproc>1 deployment,
+// each exposing its own stats socket, and merges the results into a single
+// global view.
+type HAProxyPool struct {
+	members []*HAProxyConfig
+}
+
+// NewHAProxyPool builds an HAProxyPool over the given stats endpoints, one
+// per HAProxy worker process.
+func NewHAProxyPool(endpoints ...string) (*HAProxyPool, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("haproxyctl: NewHAProxyPool requires at least one endpoint")
+	}
+
+	pool := &HAProxyPool{members: make([]*HAProxyConfig, 0, len(endpoints))}
+	for _, endpoint := range endpoints {
+		member, err := NewHAProxyConfig(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		pool.members = append(pool.members, member)
+	}
+	return pool, nil
+}
+
+// Stats fetches the Statistics of every member concurrently and merges them
+// into a single global view, keyed by (BackendName, FrontendName, ProxyID).
+func (p *HAProxyPool) Stats() (Statistics, error) {
+	type result struct {
+		stats Statistics
+		err   error
+	}
+
+	results := make([]result, len(p.members))
+	var wg sync.WaitGroup
+	for i, member := range p.members {
+		wg.Add(1)
+		go func(i int, member *HAProxyConfig) {
+			defer wg.Done()
+			stats, err := member.Stats()
+			results[i] = result{stats: stats, err: err}
+		}(i, member)
+	}
+	wg.Wait()
+
+	merged := map[statKey]*Statistic{}
+	order := make([]statKey, 0)
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		for _, stat := range r.stats {
+			key := statKey{stat.BackendName, stat.FrontendName, stat.ProxyID}
+			if existing, ok := merged[key]; ok {
+				mergeStatistic(existing, stat)
+				continue
+			}
+			s := stat
+			merged[key] = &s
+			order = append(order, key)
+		}
+	}
+
+	out := make(Statistics, 0, len(order))
+	for _, key := range order {
+		out = append(out, *merged[key])
+	}
+	return out, nil
+}
+
+// SendAction broadcasts action to every member of the pool, so a server's
+// administrative state stays consistent across all worker processes. It
+// contacts every member even if one fails, and returns a combined error
+// naming the members that failed so the caller can tell which workers are
+// left inconsistent.
+func (p *HAProxyPool) SendAction(action Action, backendName string, serverName string) error {
+	var errs []error
+	for i, member := range p.members {
+		if err := member.SendAction(action, backendName, serverName); err != nil {
+			errs = append(errs, fmt.Errorf("member %d (%s): %w", i, member.URL.String(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("haproxyctl: SendAction failed on %d/%d members: %w", len(errs), len(p.members), errors.Join(errs...))
+	}
+	return nil
+}
+
+type statKey struct {
+	backendName  string
+	frontendName string
+	proxyID      uint64
+}
+
+// mergeStatistic folds src into dst: counters are summed, *_max fields and
+// current gauges take the max across members, and Status is reconciled by
+// precedence so the worst-case state wins.
+func mergeStatistic(dst *Statistic, src Statistic) {
+	dst.SessionsTotal += src.SessionsTotal
+	dst.BytesIn += src.BytesIn
+	dst.BytesOut += src.BytesOut
+	dst.DeniedRequests += src.DeniedRequests
+	dst.DeniedResponses += src.DeniedResponses
+	dst.ErrorsRequests += src.ErrorsRequests
+	dst.ErrorsConnections += src.ErrorsConnections
+	dst.ErrorsResponses += src.ErrorsResponses
+	dst.HTTPResponse1xx += src.HTTPResponse1xx
+	dst.HTTPResponse2xx += src.HTTPResponse2xx
+	dst.HTTPResponse3xx += src.HTTPResponse3xx
+	dst.HTTPResponse4xx += src.HTTPResponse4xx
+	dst.HTTPResponse5xx += src.HTTPResponse5xx
+	dst.HTTPResponseOther += src.HTTPResponseOther
+	dst.RequestTotal += src.RequestTotal
+	dst.ConnTotal += src.ConnTotal
+	dst.CacheLookups += src.CacheLookups
+	dst.CacheHits += src.CacheHits
+
+	dst.QueueMax = maxUint64(dst.QueueMax, src.QueueMax)
+	dst.SessionsMax = maxUint64(dst.SessionsMax, src.SessionsMax)
+	dst.RateMax = maxUint64(dst.RateMax, src.RateMax)
+	dst.RequestRateMax = maxUint64(dst.RequestRateMax, src.RequestRateMax)
+	dst.ConnRateMax = maxUint64(dst.ConnRateMax, src.ConnRateMax)
+	dst.QtimeMax = maxUint64(dst.QtimeMax, src.QtimeMax)
+	dst.CtimeMax = maxUint64(dst.CtimeMax, src.CtimeMax)
+	dst.RtimeMax = maxUint64(dst.RtimeMax, src.RtimeMax)
+	dst.TtimeMax = maxUint64(dst.TtimeMax, src.TtimeMax)
+
+	dst.QueueCurrent = maxUint64(dst.QueueCurrent, src.QueueCurrent)
+	dst.SessionsCurrent = maxUint64(dst.SessionsCurrent, src.SessionsCurrent)
+	dst.Rate = maxUint64(dst.Rate, src.Rate)
+	dst.ConnRate = maxUint64(dst.ConnRate, src.ConnRate)
+
+	dst.Status = mergeStatus(dst.Status, src.Status)
+}
+
+func mergeStatus(a, b ServerStatus) ServerStatus {
+	if statusRank(b) < statusRank(a) {
+		return b
+	}
+	return a
+}
+
+// statusRank orders ServerStatus from most to least severe (DOWN > MAINT >
+// DRAIN > NOLB > UP) for reconciling a pool's members.
+func statusRank(status ServerStatus) int {
+	switch {
+	case status.IsDown():
+		return 0
+	case status.IsMaintenance():
+		return 1
+	case status.IsDraining():
+		return 2
+	case status == StatusNoLB:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func maxUint64(a, b uint64) uint64 {
+	if b > a {
+		return b
+	}
+	return a
+}
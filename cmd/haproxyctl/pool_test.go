@@ -0,0 +1,95 @@
+package haproxyctl
+
+import "testing"
+
+func TestMergeStatistic(t *testing.T) {
+	dst := Statistic{
+		SessionsTotal:     10,
+		BytesIn:           100,
+		HTTPResponseOther: 4,
+		QueueMax:          5,
+		SessionsMax:       5,
+		QueueCurrent:      2,
+		Rate:              3,
+		Status:            StatusUp,
+	}
+	src := Statistic{
+		SessionsTotal:     7,
+		BytesIn:           50,
+		HTTPResponseOther: 3,
+		QueueMax:          9,
+		SessionsMax:       2,
+		QueueCurrent:      6,
+		Rate:              1,
+		Status:            StatusDown,
+	}
+
+	mergeStatistic(&dst, src)
+
+	if dst.SessionsTotal != 17 {
+		t.Errorf("SessionsTotal = %d, want 17 (summed counter)", dst.SessionsTotal)
+	}
+	if dst.HTTPResponseOther != 7 {
+		t.Errorf("HTTPResponseOther = %d, want 7 (summed counter)", dst.HTTPResponseOther)
+	}
+	if dst.BytesIn != 150 {
+		t.Errorf("BytesIn = %d, want 150 (summed counter)", dst.BytesIn)
+	}
+	if dst.QueueMax != 9 {
+		t.Errorf("QueueMax = %d, want 9 (max of *_max)", dst.QueueMax)
+	}
+	if dst.SessionsMax != 5 {
+		t.Errorf("SessionsMax = %d, want 5 (max of *_max)", dst.SessionsMax)
+	}
+	if dst.QueueCurrent != 6 {
+		t.Errorf("QueueCurrent = %d, want 6 (max of current gauge)", dst.QueueCurrent)
+	}
+	if dst.Rate != 3 {
+		t.Errorf("Rate = %d, want 3 (max of current gauge)", dst.Rate)
+	}
+	if dst.Status != StatusDown {
+		t.Errorf("Status = %q, want %q (DOWN outranks UP)", dst.Status, StatusDown)
+	}
+}
+
+func TestMergeStatus(t *testing.T) {
+	tests := []struct {
+		a, b ServerStatus
+		want ServerStatus
+	}{
+		{a: StatusUp, b: StatusDown, want: StatusDown},
+		{a: StatusDown, b: StatusUp, want: StatusDown},
+		{a: StatusMaint, b: StatusDrain, want: StatusMaint},
+		{a: StatusDrain, b: StatusNoLB, want: StatusDrain},
+		{a: StatusNoLB, b: StatusUp, want: StatusNoLB},
+		{a: StatusUp, b: StatusUp, want: StatusUp},
+		{a: "UP 2/3", b: StatusDown, want: StatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.a)+"/"+string(tt.b), func(t *testing.T) {
+			if got := mergeStatus(tt.a, tt.b); got != tt.want {
+				t.Errorf("mergeStatus(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusRankPrecedence(t *testing.T) {
+	order := []ServerStatus{StatusDown, StatusMaint, StatusDrain, StatusNoLB, StatusUp}
+	for i := 1; i < len(order); i++ {
+		if statusRank(order[i-1]) >= statusRank(order[i]) {
+			t.Errorf("statusRank(%q) = %d should be lower than statusRank(%q) = %d",
+				order[i-1], statusRank(order[i-1]), order[i], statusRank(order[i]))
+		}
+	}
+}
+
+func TestMaxUint64(t *testing.T) {
+	if got := maxUint64(3, 7); got != 7 {
+		t.Errorf("maxUint64(3, 7) = %d, want 7", got)
+	}
+	if got := maxUint64(7, 3); got != 7 {
+		t.Errorf("maxUint64(7, 3) = %d, want 7", got)
+	}
+}
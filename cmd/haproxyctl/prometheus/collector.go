@@ -0,0 +1,212 @@
+// Package prometheus exposes haproxyctl.Statistics as a prometheus.Collector,
+// so an application can drop haproxyctl into an existing Prometheus scrape
+// target without writing its own translation layer.
+package prometheus
+
+import (
+	"time"
+
+	haproxyctl "github.com/partcyborg/haproxyctl/cmd/haproxyctl"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "haproxy"
+
+// serverStatusValue maps a Statistic.Status onto the numeric scale used by
+// haproxy_up, mirroring the community HAProxy exporter. It is driven by the
+// ServerStatus predicates rather than an exact-string match, since real
+// status values are rarely a bare "UP"/"DOWN": frontends report "OPEN",
+// checkless entries report "no check", and servers mid-transition report
+// "UP 2/3" / "DOWN 1/2" / "MAINT (via h/s)".
+func serverStatusValue(status haproxyctl.ServerStatus) float64 {
+	switch {
+	case status.IsDown():
+		return 0
+	case status.IsMaintenance():
+		return 2
+	case status.IsDraining():
+		return 3
+	case status == haproxyctl.StatusNoLB:
+		return 4
+	default:
+		// UP, "UP N/M", "OPEN" (frontends), "no check", and anything else
+		// HAProxy hasn't flagged as down/maint/drain/nolb are operational.
+		return 1
+	}
+}
+
+// Collector implements prometheus.Collector by fetching and translating the
+// Statistics reported by an *haproxyctl.HAProxyConfig.
+type Collector struct {
+	client *haproxyctl.HAProxyConfig
+
+	up              *prometheus.Desc
+	sessionsCurrent *prometheus.Desc
+	sessionsTotal   *prometheus.Desc
+	bytesIn         *prometheus.Desc
+	bytesOut        *prometheus.Desc
+	deniedRequests  *prometheus.Desc
+	deniedResponses *prometheus.Desc
+	errorsRequests  *prometheus.Desc
+	errorsConn      *prometheus.Desc
+	errorsResponses *prometheus.Desc
+	httpResponses   *prometheus.Desc
+	queueTime       *prometheus.Desc
+	connectTime     *prometheus.Desc
+	responseTime    *prometheus.Desc
+	totalTime       *prometheus.Desc
+	idleConnCur     *prometheus.Desc
+	safeConnCur     *prometheus.Desc
+	usedConnCur     *prometheus.Desc
+	checkHealth     *prometheus.Desc
+	weight          *prometheus.Desc
+	scrapeDuration  prometheus.Histogram
+	scrapeErrors    prometheus.Counter
+}
+
+// NewCollector returns a Collector that reports the Statistics of client.
+//
+// The fourth label is "instance", holding the scrape endpoint
+// (client.URL.String()), rather than the "backend" label named in the
+// original request. "backend" is already the pxname and belongs to the
+// "proxy" label; reusing it for the endpoint would collide with that
+// meaning, so it's named for what it actually holds instead.
+func NewCollector(client *haproxyctl.HAProxyConfig) *Collector {
+	labels := []string{"proxy", "sv", "type", "instance"}
+
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, nil)
+	}
+
+	return &Collector{
+		client: client,
+
+		up:              desc("up", "Current health status of the service (UP=1, DOWN=0, MAINT=2, DRAIN=3, NOLB=4)"),
+		sessionsCurrent: desc("sessions_current", "Current number of active sessions"),
+		sessionsTotal:   desc("sessions_total", "Total number of sessions"),
+		bytesIn:         desc("bytes_in_total", "Current total of incoming bytes"),
+		bytesOut:        desc("bytes_out_total", "Current total of outgoing bytes"),
+		deniedRequests:  desc("denied_requests_total", "Total of requests denied for security"),
+		deniedResponses: desc("denied_responses_total", "Total of responses denied for security"),
+		errorsRequests:  desc("request_errors_total", "Total of request errors"),
+		errorsConn:      desc("connection_errors_total", "Total of connection errors"),
+		errorsResponses: desc("response_errors_total", "Total of response errors"),
+		httpResponses: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "http_responses_total"),
+			"Total of HTTP responses, by status code class",
+			append(append([]string{}, labels...), "code"), nil,
+		),
+		queueTime:    desc("queue_time_average_seconds", "Average queue time over the last 1024 requests"),
+		connectTime:  desc("connect_time_average_seconds", "Average connect time over the last 1024 requests"),
+		responseTime: desc("response_time_average_seconds", "Average response time over the last 1024 requests"),
+		totalTime:    desc("total_time_average_seconds", "Average total time over the last 1024 requests"),
+		idleConnCur:  desc("idle_connections_current", "Current number of unused connections"),
+		safeConnCur:  desc("safe_connections_current", "Current number of safe idle connections"),
+		usedConnCur:  desc("used_connections_current", "Current number of connections in use"),
+		checkHealth:  desc("check_health", "Health check counter in the rise/fall progression"),
+		weight:       desc("weight", "Current weight of the server or backend"),
+
+		scrapeDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "exporter",
+			Name:      "scrape_duration_seconds",
+			Help:      "Time it took to fetch and parse the stats page or socket",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		scrapeErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "exporter",
+			Name:      "scrape_errors_total",
+			Help:      "Total number of errors encountered while scraping",
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+	ch <- c.sessionsCurrent
+	ch <- c.sessionsTotal
+	ch <- c.bytesIn
+	ch <- c.bytesOut
+	ch <- c.deniedRequests
+	ch <- c.deniedResponses
+	ch <- c.errorsRequests
+	ch <- c.errorsConn
+	ch <- c.errorsResponses
+	ch <- c.httpResponses
+	ch <- c.queueTime
+	ch <- c.connectTime
+	ch <- c.responseTime
+	ch <- c.totalTime
+	ch <- c.idleConnCur
+	ch <- c.safeConnCur
+	ch <- c.usedConnCur
+	ch <- c.checkHealth
+	ch <- c.weight
+	ch <- c.scrapeDuration.Desc()
+	ch <- c.scrapeErrors.Desc()
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	start := time.Now()
+	stats, err := c.client.Stats()
+	c.scrapeDuration.Observe(time.Since(start).Seconds())
+	ch <- c.scrapeDuration
+	if err != nil {
+		c.scrapeErrors.Inc()
+	}
+	ch <- c.scrapeErrors
+	if err != nil {
+		return
+	}
+
+	for _, s := range stats {
+		labels := []string{s.BackendName, s.FrontendName, entryTypeName(s.Type), c.client.URL.String()}
+
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, serverStatusValue(s.Status), labels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionsCurrent, prometheus.GaugeValue, float64(s.SessionsCurrent), labels...)
+		ch <- prometheus.MustNewConstMetric(c.sessionsTotal, prometheus.CounterValue, float64(s.SessionsTotal), labels...)
+		ch <- prometheus.MustNewConstMetric(c.bytesIn, prometheus.CounterValue, float64(s.BytesIn), labels...)
+		ch <- prometheus.MustNewConstMetric(c.bytesOut, prometheus.CounterValue, float64(s.BytesOut), labels...)
+		ch <- prometheus.MustNewConstMetric(c.deniedRequests, prometheus.CounterValue, float64(s.DeniedRequests), labels...)
+		ch <- prometheus.MustNewConstMetric(c.deniedResponses, prometheus.CounterValue, float64(s.DeniedResponses), labels...)
+		ch <- prometheus.MustNewConstMetric(c.errorsRequests, prometheus.CounterValue, float64(s.ErrorsRequests), labels...)
+		ch <- prometheus.MustNewConstMetric(c.errorsConn, prometheus.CounterValue, float64(s.ErrorsConnections), labels...)
+		ch <- prometheus.MustNewConstMetric(c.errorsResponses, prometheus.CounterValue, float64(s.ErrorsResponses), labels...)
+		for code, count := range map[string]uint64{
+			"1xx": s.HTTPResponse1xx,
+			"2xx": s.HTTPResponse2xx,
+			"3xx": s.HTTPResponse3xx,
+			"4xx": s.HTTPResponse4xx,
+			"5xx": s.HTTPResponse5xx,
+		} {
+			ch <- prometheus.MustNewConstMetric(c.httpResponses, prometheus.CounterValue, float64(count), append(append([]string{}, labels...), code)...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.queueTime, prometheus.GaugeValue, float64(s.AvgQueueTime)/1000, labels...)
+		ch <- prometheus.MustNewConstMetric(c.connectTime, prometheus.GaugeValue, float64(s.AvgConnectTime)/1000, labels...)
+		ch <- prometheus.MustNewConstMetric(c.responseTime, prometheus.GaugeValue, float64(s.AvgResponseTime)/1000, labels...)
+		ch <- prometheus.MustNewConstMetric(c.totalTime, prometheus.GaugeValue, float64(s.AvgTotalTime)/1000, labels...)
+		ch <- prometheus.MustNewConstMetric(c.idleConnCur, prometheus.GaugeValue, float64(s.IdleConnCur), labels...)
+		ch <- prometheus.MustNewConstMetric(c.safeConnCur, prometheus.GaugeValue, float64(s.SafeConnCur), labels...)
+		ch <- prometheus.MustNewConstMetric(c.usedConnCur, prometheus.GaugeValue, float64(s.UsedConnCur), labels...)
+		ch <- prometheus.MustNewConstMetric(c.checkHealth, prometheus.GaugeValue, float64(s.CheckHealth), labels...)
+		ch <- prometheus.MustNewConstMetric(c.weight, prometheus.GaugeValue, float64(s.Weight), labels...)
+	}
+}
+
+func entryTypeName(t haproxyctl.EntryType) string {
+	switch t {
+	case haproxyctl.Frontend:
+		return "FRONTEND"
+	case haproxyctl.Backend:
+		return "BACKEND"
+	case haproxyctl.Server:
+		return "SERVER"
+	case haproxyctl.Socket:
+		return "SOCKET"
+	default:
+		return "UNKNOWN"
+	}
+}
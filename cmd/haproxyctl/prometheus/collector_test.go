@@ -0,0 +1,60 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	haproxyctl "github.com/partcyborg/haproxyctl/cmd/haproxyctl"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestServerStatusValue(t *testing.T) {
+	tests := []struct {
+		status haproxyctl.ServerStatus
+		want   float64
+	}{
+		{haproxyctl.StatusUp, 1},
+		{"UP 2/3", 1},
+		{"OPEN", 1},
+		{haproxyctl.StatusNoCheck, 1},
+		{haproxyctl.StatusDown, 0},
+		{"DOWN 1/2", 0},
+		{haproxyctl.StatusMaint, 2},
+		{haproxyctl.StatusDrain, 3},
+		{haproxyctl.StatusNoLB, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := serverStatusValue(tt.status); got != tt.want {
+				t.Errorf("serverStatusValue(%q) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectorCollect(t *testing.T) {
+	const csv = "# pxname,svname,status,type,\n" +
+		"web,BACKEND,UP,1,\n" +
+		"web,web1,DOWN,2,\n"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(csv))
+	}))
+	defer srv.Close()
+
+	client, err := haproxyctl.NewHAProxyConfig(srv.URL + "/stats")
+	if err != nil {
+		t.Fatalf("NewHAProxyConfig: %v", err)
+	}
+
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(NewCollector(client)); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if _, err := reg.Gather(); err != nil {
+		t.Fatalf("Gather: %v (Collect likely emitted a duplicate or malformed metric)", err)
+	}
+}
@@ -0,0 +1,33 @@
+package haproxyctl
+
+import "io"
+
+// ShowServersState runs "show servers state" over the Runtime API socket,
+// returning the raw per-server state table HAProxy reports over the socket.
+// The caller reads until EOF and must Close it when done.
+func (c *HAProxyConfig) ShowServersState() (io.ReadCloser, error) {
+	if !c.IsSocket() {
+		return nil, errNotSocket("ShowServersState")
+	}
+	return c.RunCommand("show servers state")
+}
+
+// ShowPools runs "show pools" over the Runtime API socket, returning
+// HAProxy's memory pool usage report. The caller reads until EOF and must
+// Close it when done.
+func (c *HAProxyConfig) ShowPools() (io.ReadCloser, error) {
+	if !c.IsSocket() {
+		return nil, errNotSocket("ShowPools")
+	}
+	return c.RunCommand("show pools")
+}
+
+// ShowSessions runs "show sess" over the Runtime API socket, returning a
+// dump of HAProxy's currently active sessions. There is no HTTP stats page
+// equivalent. The caller reads until EOF and must Close it when done.
+func (c *HAProxyConfig) ShowSessions() (io.ReadCloser, error) {
+	if !c.IsSocket() {
+		return nil, errNotSocket("ShowSessions")
+	}
+	return c.RunCommand("show sess")
+}
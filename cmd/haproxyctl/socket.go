@@ -0,0 +1,65 @@
+package haproxyctl
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+)
+
+// IsSocket reports whether c talks to a Runtime API socket (unix:// or
+// tcp://) rather than the HTTP stats page.
+func (c *HAProxyConfig) IsSocket() bool {
+	switch c.URL.Scheme {
+	case "unix", "tcp":
+		return true
+	default:
+		return false
+	}
+}
+
+// RunCommand sends cmd to the HAProxy Runtime API socket and returns the
+// live connection, positioned to read the response. The caller reads until
+// EOF and must Close it when done. It is the primitive that Stats, Info and
+// the action helpers are built on for socket-based configs.
+func (c *HAProxyConfig) RunCommand(cmd string) (io.ReadCloser, error) {
+	conn, err := c.dialSocket()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.TimeoutOp > 0 {
+		if err := conn.SetDeadline(time.Now().Add(c.TimeoutOp)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if _, err := fmt.Fprintf(conn, "%s\n", cmd); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *HAProxyConfig) dialSocket() (net.Conn, error) {
+	network, address, err := socketDialTarget(c.URL)
+	if err != nil {
+		return nil, err
+	}
+	dialer := net.Dialer{Timeout: c.Timeout}
+	return dialer.Dial(network, address)
+}
+
+func socketDialTarget(u url.URL) (network string, address string, err error) {
+	switch u.Scheme {
+	case "unix":
+		return "unix", u.Path, nil
+	case "tcp":
+		return "tcp", u.Host, nil
+	default:
+		return "", "", fmt.Errorf("haproxyctl: %q is not a Runtime API socket URL", u.String())
+	}
+}
@@ -0,0 +1,62 @@
+package haproxyctl
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Stats fetches and parses the current HAProxy statistics, over the Runtime
+// API socket if one is configured, or the HTTP stats page otherwise.
+func (c *HAProxyConfig) Stats() (Statistics, error) {
+	r, err := c.statsReader()
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalStatistics(r)
+}
+
+func (c *HAProxyConfig) statsReader() (io.ReadCloser, error) {
+	if c.IsSocket() {
+		return c.RunCommand("show stat")
+	}
+	return c.get(c.StatsPath + ";csv;norefresh")
+}
+
+// Info returns the key/value pairs reported by HAProxy's "show info"
+// Runtime API command. It requires a socket transport; HAProxy does not
+// expose this information over the HTTP stats page.
+func (c *HAProxyConfig) Info() (map[string]string, error) {
+	if !c.IsSocket() {
+		return nil, errNotSocket("Info")
+	}
+
+	r, err := c.RunCommand("show info")
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	info := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		info[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return info, scanner.Err()
+}
+
+func errNotSocket(op string) error {
+	return &socketOnlyError{op: op}
+}
+
+type socketOnlyError struct {
+	op string
+}
+
+func (e *socketOnlyError) Error() string {
+	return "haproxyctl: " + e.op + " requires a unix:// or tcp:// Runtime API socket endpoint"
+}
@@ -0,0 +1,97 @@
+package haproxyctl
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ServerStatus is the operational status HAProxy reports in the "status"
+// stats column for a frontend, backend or server, e.g. "UP", "DOWN",
+// "MAINT", "DRAIN", "NOLB", "no check", or a health-check transition like
+// "UP 2/3".
+type ServerStatus string
+
+const (
+	StatusUp      ServerStatus = "UP"
+	StatusDown    ServerStatus = "DOWN"
+	StatusMaint   ServerStatus = "MAINT"
+	StatusDrain   ServerStatus = "DRAIN"
+	StatusNoLB    ServerStatus = "NOLB"
+	StatusNoCheck ServerStatus = "no check"
+)
+
+// IsUp reports whether status is UP, including the "UP N/M" transitional form.
+func (status ServerStatus) IsUp() bool {
+	return hasStatusPrefix(status, StatusUp)
+}
+
+// IsDown reports whether status is DOWN, including the "DOWN N/M" and
+// "DOWN (agent)" forms.
+func (status ServerStatus) IsDown() bool {
+	return hasStatusPrefix(status, StatusDown)
+}
+
+// IsMaintenance reports whether status is MAINT, including the
+// "MAINT (via host/server)" form.
+func (status ServerStatus) IsMaintenance() bool {
+	return hasStatusPrefix(status, StatusMaint)
+}
+
+// IsDraining reports whether status is DRAIN.
+func (status ServerStatus) IsDraining() bool {
+	return hasStatusPrefix(status, StatusDrain)
+}
+
+func hasStatusPrefix(status ServerStatus, prefix ServerStatus) bool {
+	s := string(status)
+	p := string(prefix)
+	return s == p || (len(s) > len(p) && s[:len(p)] == p && s[len(p)] == ' ')
+}
+
+// HealthCheckProgress parses the "UP 2/3" / "DOWN 1/2" form HAProxy reports
+// while a server transitions between states, returning the current
+// consecutive check count and the rise/fall threshold it is climbing
+// towards. Both are zero if status is not in that form.
+func (status ServerStatus) HealthCheckProgress() (current, rise int) {
+	s := string(status)
+	i := strings.LastIndexByte(s, ' ')
+	if i < 0 {
+		return 0, 0
+	}
+	curStr, riseStr, ok := strings.Cut(s[i+1:], "/")
+	if !ok {
+		return 0, 0
+	}
+	current, err1 := strconv.Atoi(curStr)
+	rise, err2 := strconv.Atoi(riseStr)
+	if err1 != nil || err2 != nil {
+		return 0, 0
+	}
+	return current, rise
+}
+
+// Filter returns the subset of s for which pred returns true.
+func (s Statistics) Filter(pred func(Statistic) bool) Statistics {
+	filtered := make(Statistics, 0, len(s))
+	for _, stat := range s {
+		if pred(stat) {
+			filtered = append(filtered, stat)
+		}
+	}
+	return filtered
+}
+
+// OnlyBackends returns the BACKEND entries in s.
+func (s Statistics) OnlyBackends() Statistics {
+	return s.Filter(func(stat Statistic) bool { return stat.Type == Backend })
+}
+
+// OnlyServers returns the SERVER entries in s.
+func (s Statistics) OnlyServers() Statistics {
+	return s.Filter(func(stat Statistic) bool { return stat.Type == Server })
+}
+
+// DownServers returns the SERVER entries in s currently reporting DOWN.
+func (s Statistics) DownServers() Statistics {
+	return s.OnlyServers().Filter(func(stat Statistic) bool { return stat.Status.IsDown() })
+}
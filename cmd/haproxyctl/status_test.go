@@ -0,0 +1,89 @@
+package haproxyctl
+
+import "testing"
+
+func TestServerStatusPredicates(t *testing.T) {
+	tests := []struct {
+		status        ServerStatus
+		isUp          bool
+		isDown        bool
+		isMaintenance bool
+		isDraining    bool
+	}{
+		{status: "UP", isUp: true},
+		{status: "UP 2/3", isUp: true},
+		{status: "DOWN", isDown: true},
+		{status: "DOWN 1/2", isDown: true},
+		{status: "DOWN (agent)", isDown: true},
+		{status: "MAINT", isMaintenance: true},
+		{status: "MAINT (via host/server)", isMaintenance: true},
+		{status: "DRAIN", isDraining: true},
+		{status: "NOLB", isUp: false, isDown: false, isMaintenance: false, isDraining: false},
+		{status: "no check"},
+		{status: "OPEN"},
+		// "UPSTAIRS" is not "UP" followed by a space, so it must not match IsUp.
+		{status: "UPSTAIRS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.IsUp(); got != tt.isUp {
+				t.Errorf("IsUp() = %v, want %v", got, tt.isUp)
+			}
+			if got := tt.status.IsDown(); got != tt.isDown {
+				t.Errorf("IsDown() = %v, want %v", got, tt.isDown)
+			}
+			if got := tt.status.IsMaintenance(); got != tt.isMaintenance {
+				t.Errorf("IsMaintenance() = %v, want %v", got, tt.isMaintenance)
+			}
+			if got := tt.status.IsDraining(); got != tt.isDraining {
+				t.Errorf("IsDraining() = %v, want %v", got, tt.isDraining)
+			}
+		})
+	}
+}
+
+func TestHealthCheckProgress(t *testing.T) {
+	tests := []struct {
+		status      ServerStatus
+		wantCurrent int
+		wantRise    int
+	}{
+		{status: "UP 2/3", wantCurrent: 2, wantRise: 3},
+		{status: "DOWN 1/2", wantCurrent: 1, wantRise: 2},
+		{status: "UP", wantCurrent: 0, wantRise: 0},
+		{status: "MAINT (via host/server)", wantCurrent: 0, wantRise: 0},
+		{status: "no check", wantCurrent: 0, wantRise: 0},
+		{status: "", wantCurrent: 0, wantRise: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			current, rise := tt.status.HealthCheckProgress()
+			if current != tt.wantCurrent || rise != tt.wantRise {
+				t.Errorf("HealthCheckProgress() = (%d, %d), want (%d, %d)", current, rise, tt.wantCurrent, tt.wantRise)
+			}
+		})
+	}
+}
+
+func TestStatisticsFilters(t *testing.T) {
+	stats := Statistics{
+		{BackendName: "web", FrontendName: "FRONTEND", Type: Frontend, Status: "OPEN"},
+		{BackendName: "web", FrontendName: "BACKEND", Type: Backend, Status: "UP"},
+		{BackendName: "web", FrontendName: "web1", Type: Server, Status: "UP"},
+		{BackendName: "web", FrontendName: "web2", Type: Server, Status: "DOWN"},
+	}
+
+	if got := len(stats.OnlyBackends()); got != 1 {
+		t.Errorf("OnlyBackends: got %d entries, want 1", got)
+	}
+	if got := len(stats.OnlyServers()); got != 2 {
+		t.Errorf("OnlyServers: got %d entries, want 2", got)
+	}
+
+	down := stats.DownServers()
+	if len(down) != 1 || down[0].FrontendName != "web2" {
+		t.Errorf("DownServers: got %+v, want only web2", down)
+	}
+}
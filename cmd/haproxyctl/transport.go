@@ -0,0 +1,56 @@
+package haproxyctl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// get issues an HTTP GET against path, relative to the configured stats
+// page URL, and returns the live response body. The caller reads until EOF
+// and must Close it when done.
+func (c *HAProxyConfig) get(path string) (io.ReadCloser, error) {
+	return c.do(http.MethodGet, path, nil)
+}
+
+// post issues an HTTP POST of a form-encoded body against path, relative to
+// the configured stats page URL, and returns the live response body. The
+// caller reads until EOF and must Close it when done.
+func (c *HAProxyConfig) post(path string, form url.Values) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodPost, c.URL.String()+path, bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.send(req)
+}
+
+func (c *HAProxyConfig) do(method string, path string, body io.Reader) (io.ReadCloser, error) {
+	req, err := http.NewRequest(method, c.URL.String()+path, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(req)
+}
+
+func (c *HAProxyConfig) send(req *http.Request) (io.ReadCloser, error) {
+	c.setupClient()
+
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("haproxyctl: unexpected status %s from %s", resp.Status, req.URL)
+	}
+
+	return resp.Body, nil
+}